@@ -0,0 +1,19 @@
+package autoscaler
+
+import (
+	"github.com/codeready-toolchain/member-operator/pkg/autoscaler"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewCmd returns the `autoscaler` command group, with its subcommands (currently just `plan`)
+// already registered. This is the entry point member-operator-cli's root command should mount.
+func NewCmd(cl client.Client, namespace string, pools func() []autoscaler.BufferPoolSpec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "autoscaler",
+		Short: "Manage the autoscaling buffer deployments",
+	}
+	cmd.AddCommand(NewPlanCmd(cl, namespace, pools))
+	return cmd
+}
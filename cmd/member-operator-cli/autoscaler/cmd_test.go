@@ -0,0 +1,63 @@
+package autoscaler
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/codeready-toolchain/member-operator/pkg/autoscaler"
+	"github.com/codeready-toolchain/member-operator/pkg/controller/memberstatus"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestNewCmd_PlanIsReachable asserts that `plan` is registered under the `autoscaler` command
+// group NewCmd returns, so `member-operator-cli autoscaler plan` actually resolves instead of
+// only existing as an unregistered *cobra.Command value.
+func TestNewCmd_PlanIsReachable(t *testing.T) {
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{
+		corev1.AddToScheme,
+		appsv1.AddToScheme,
+		schedulingv1.AddToScheme,
+	} {
+		if err := add(scheme); err != nil {
+			t.Fatal(err)
+		}
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-1",
+			Labels: map[string]string{memberstatus.LabelNodeRoleWorker: ""},
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceMemory: *resource.NewScaledQuantity(100, resource.Giga),
+				corev1.ResourceCPU:    *resource.NewMilliQuantity(100_000, resource.DecimalSI),
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(node).Build()
+
+	cmd := NewCmd(cl, "toolchain-member-operator", func() []autoscaler.BufferPoolSpec { return nil })
+
+	found, _, err := cmd.Find([]string{"plan"})
+	if err != nil {
+		t.Fatalf("expected \"plan\" to be registered under \"autoscaler\": %v", err)
+	}
+	if found.Use != "plan" {
+		t.Fatalf("expected to find the plan command, got %q", found.Use)
+	}
+
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"plan"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("expected autoscaler plan to run: %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+// Package autoscaler provides the `autoscaler` command group for member-operator-cli.
+package autoscaler
+
+import (
+	"fmt"
+
+	"github.com/codeready-toolchain/member-operator/pkg/autoscaler"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewPlanCmd returns the `autoscaler plan` subcommand, which prints the computed autoscaling
+// buffer plan - replica counts, per-replica CPU/memory, target nodes, priority class values, and a
+// diff against any existing Deployment/PriorityClass - without touching the cluster. It is meant
+// to be registered under the CLI's `autoscaler` command group alongside any other autoscaler
+// subcommands.
+func NewPlanCmd(cl client.Client, namespace string, pools func() []autoscaler.BufferPoolSpec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "plan",
+		Short: "Print the autoscaling buffer plan without changing the cluster",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			plan, err := autoscaler.PlanBuffer(cl, namespace, pools())
+			if err != nil {
+				return err
+			}
+			printPlan(cmd, plan)
+			return nil
+		},
+	}
+}
+
+func printPlan(cmd *cobra.Command, plan autoscaler.BufferPlan) {
+	out := cmd.OutOrStdout()
+	for _, pool := range plan.Pools {
+		fmt.Fprintf(out, "pool %q:\n", pool.DeploymentName)
+		fmt.Fprintf(out, "  replicas:            %d\n", pool.Replicas)
+		fmt.Fprintf(out, "  memory per replica:  %s\n", pool.MemoryPerReplica.String())
+		fmt.Fprintf(out, "  cpu per replica:     %s\n", pool.CPUPerReplica.String())
+		fmt.Fprintf(out, "  priority class:      %s (value %d)\n", pool.PriorityClassName, pool.PriorityClassValue)
+		fmt.Fprintf(out, "  target nodes:        %v\n", pool.TargetNodes)
+		fmt.Fprintf(out, "  deployment:          %s\n", pool.DeploymentDiff)
+		fmt.Fprintf(out, "  priority class:      %s\n", pool.PriorityClassDiff)
+	}
+}
@@ -3,7 +3,9 @@ package autoscaler
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math"
+	"reflect"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -16,45 +18,147 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
-	bufferSizeNodeSizeRatio = 0.8 // The buffer size is 80% of allocatable memory of a worker node
+	bufferSizeNodeSizeRatio = 0.8 // The buffer size is 80% of allocatable memory/cpu of a worker node
+
+	// defaultBufferReplicas is the number of buffer pods to spread across a pool's worker nodes
+	// when a BufferPoolSpec does not override it. Each replica pre-provisions one spare node.
+	defaultBufferReplicas = 1
+
+	defaultPriorityClassValue = -100
 
 	priorityClassName = "autoscaling-buffer"
 	bufferAppName     = "autoscaling-buffer"
 )
 
-func EnsureBuffer(cl client.Client, namespace string) error {
-	if err := ensurePriorityClass(cl); err != nil {
-		return err
+// BufferPoolSpec describes one pool of worker nodes that should be kept pre-provisioned with its
+// own buffer deployment - for example the default pool of regular workers, a GPU node pool, a
+// memory-optimized pool, or a tainted pool reserved for a specific tenant.
+type BufferPoolSpec struct {
+	// Name identifies the pool and is used to derive the deployment and priority class names
+	// (e.g. "autoscaling-buffer-gpu"). Leave empty for the default pool, which keeps the
+	// original "autoscaling-buffer" names.
+	Name string
+
+	// NodeSelectorTerm selects the worker nodes that belong to this pool. An empty term matches
+	// every worker node.
+	NodeSelectorTerm corev1.NodeSelectorTerm
+
+	// Tolerations are copied onto the buffer pod so it can be scheduled onto this pool's nodes
+	// even when they are tainted.
+	Tolerations []corev1.Toleration
+
+	// Replicas is the number of buffer pods to spread across this pool's nodes, one per node.
+	// Defaults to defaultBufferReplicas when 0.
+	Replicas int32
+
+	// ResourceRatio overrides bufferSizeNodeSizeRatio for this pool. Defaults to
+	// bufferSizeNodeSizeRatio when 0.
+	ResourceRatio float64
+
+	// PriorityClassValue overrides defaultPriorityClassValue for this pool.
+	PriorityClassValue int32
+}
+
+func (p BufferPoolSpec) replicas() int32 {
+	if p.Replicas < 1 {
+		return defaultBufferReplicas
 	}
+	return p.Replicas
+}
 
-	if err := ensureBufferDeployment(cl, namespace); err != nil {
+func (p BufferPoolSpec) resourceRatio() float64 {
+	if p.ResourceRatio <= 0 {
+		return bufferSizeNodeSizeRatio
+	}
+	return p.ResourceRatio
+}
+
+func (p BufferPoolSpec) priorityClassValue() int32 {
+	if p.PriorityClassValue != 0 {
+		return p.PriorityClassValue
+	}
+	return defaultPriorityClassValue
+}
+
+func (p BufferPoolSpec) deploymentName() string {
+	if p.Name == "" {
+		return bufferAppName
+	}
+	return fmt.Sprintf("%s-%s", bufferAppName, p.Name)
+}
+
+func (p BufferPoolSpec) priorityClassName() string {
+	if p.Name == "" {
+		return priorityClassName
+	}
+	return fmt.Sprintf("%s-%s", priorityClassName, p.Name)
+}
+
+// BufferDeploymentNames returns the names of the Deployments EnsureBuffer manages for the given
+// pools, in the same order, for callers (e.g. the MemberStatus reconciler) that need to look the
+// Deployments up without duplicating the pool-to-deployment-name logic.
+func BufferDeploymentNames(pools []BufferPoolSpec) []string {
+	if len(pools) == 0 {
+		pools = []BufferPoolSpec{{}}
+	}
+	names := make([]string, 0, len(pools))
+	for _, pool := range pools {
+		names = append(names, pool.deploymentName())
+	}
+	return names
+}
+
+// EnsureBuffer makes sure that, for every given pool, the autoscaling buffer priority class and
+// deployment exist and are configured to pre-provision capacity on that pool's worker nodes. When
+// no pools are given, a single default pool covering all worker nodes is reconciled, matching the
+// original single-pool behaviour. Each pool's replicas are spread one-per-node via topology spread
+// constraints and pod anti-affinity, so cluster-autoscaler keeps that many of the pool's nodes warm.
+func EnsureBuffer(cl client.Client, namespace string, pools []BufferPoolSpec) error {
+	if len(pools) == 0 {
+		pools = []BufferPoolSpec{{}}
+	}
+	for _, pool := range pools {
+		if err := ensureBufferPool(cl, namespace, pool); err != nil {
+			return fmt.Errorf("failed to ensure buffer pool %q: %w", pool.deploymentName(), err)
+		}
+	}
+	return nil
+}
+
+func ensureBufferPool(cl client.Client, namespace string, pool BufferPoolSpec) error {
+	if err := ensurePriorityClass(cl, pool); err != nil {
+		return err
+	}
+	if err := ensureBufferDeployment(cl, namespace, pool); err != nil {
 		return err
 	}
 	return nil
 }
 
-func ensurePriorityClass(cl client.Client) error {
+func ensurePriorityClass(cl client.Client, pool BufferPoolSpec) error {
 	pc := &schedulingv1.PriorityClass{}
-	if err := cl.Get(context.TODO(), types.NamespacedName{Name: priorityClassName}, pc); err != nil {
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: pool.priorityClassName()}, pc); err != nil {
 		if k8serrors.IsNotFound(err) {
-			return createPriorityClass(cl)
+			return createPriorityClass(cl, pool)
 		}
 		return err
 	}
-	updated := patchPriorityClassObj(pc)
+	updated := patchPriorityClassObj(pc, pool)
 	if updated {
 		for i := 0; i < 10; i++ { // Try 10 times in case of conflict before giving up
 			if err := cl.Update(context.TODO(), pc); err != nil {
 				if k8serrors.IsConflict(err) {
 					// Re-load and re-try
 					pc = &schedulingv1.PriorityClass{}
-					if err := cl.Get(context.TODO(), types.NamespacedName{Name: priorityClassName}, pc); err != nil {
+					if err := cl.Get(context.TODO(), types.NamespacedName{Name: pool.priorityClassName()}, pc); err != nil {
 						return err
 					}
+					patchPriorityClassObj(pc, pool)
 					continue
 				}
 				return err
@@ -67,16 +171,20 @@ func ensurePriorityClass(cl client.Client) error {
 	return nil
 }
 
-func createPriorityClass(cl client.Client) error {
-	pc := &schedulingv1.PriorityClass{}
-	patchPriorityClassObj(pc)
+func createPriorityClass(cl client.Client, pool BufferPoolSpec) error {
+	pc := &schedulingv1.PriorityClass{
+		ObjectMeta: v1.ObjectMeta{
+			Name: pool.priorityClassName(),
+		},
+	}
+	patchPriorityClassObj(pc, pool)
 	return cl.Create(context.TODO(), pc)
 }
 
-func patchPriorityClassObj(pc *schedulingv1.PriorityClass) bool {
+func patchPriorityClassObj(pc *schedulingv1.PriorityClass, pool BufferPoolSpec) bool {
 	updated := patchLabels(&pc.ObjectMeta, toolchainv1alpha1.ProviderLabelKey, toolchainv1alpha1.ProviderLabelValue)
-	if pc.Value != -100 {
-		pc.Value = -100
+	if pc.Value != pool.priorityClassValue() {
+		pc.Value = pool.priorityClassValue()
 		updated = true
 	}
 	if pc.GlobalDefault {
@@ -91,29 +199,30 @@ func patchPriorityClassObj(pc *schedulingv1.PriorityClass) bool {
 	return updated
 }
 
-func ensureBufferDeployment(cl client.Client, namespace string) error {
-	bufferSizeGi, err := bufferSizeGi(cl)
+func ensureBufferDeployment(cl client.Client, namespace string, pool BufferPoolSpec) error {
+	memGi, cpuMilli, err := bufferSize(cl, pool)
 	if err != nil {
 		return err
 	}
 
 	dt := &appsv1.Deployment{}
-	if err := cl.Get(context.TODO(), types.NamespacedName{Name: bufferAppName, Namespace: namespace}, dt); err != nil {
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: pool.deploymentName(), Namespace: namespace}, dt); err != nil {
 		if k8serrors.IsNotFound(err) {
-			return createBufferDeployment(cl)
+			return createBufferDeployment(cl, namespace, pool, memGi, cpuMilli)
 		}
 		return err
 	}
-	updated := patchBufferDeployment(dt)
+	updated := patchBufferDeployment(dt, pool, memGi, cpuMilli)
 	if updated {
 		for i := 0; i < 10; i++ { // Try 10 times in case of conflict before giving up
-			if err := cl.Update(context.TODO(), pc); err != nil {
+			if err := cl.Update(context.TODO(), dt); err != nil {
 				if k8serrors.IsConflict(err) {
 					// Re-load and re-try
-					pc = &schedulingv1.PriorityClass{}
-					if err := cl.Get(context.TODO(), types.NamespacedName{Name: priorityClassName}, pc); err != nil {
+					dt = &appsv1.Deployment{}
+					if err := cl.Get(context.TODO(), types.NamespacedName{Name: pool.deploymentName(), Namespace: namespace}, dt); err != nil {
 						return err
 					}
+					patchBufferDeployment(dt, pool, memGi, cpuMilli)
 					continue
 				}
 				return err
@@ -126,10 +235,15 @@ func ensureBufferDeployment(cl client.Client, namespace string) error {
 	return nil
 }
 
-func createBufferDeployment(cl client.Client) error {
-	pc := &schedulingv1.PriorityClass{}
-	patchPriorityClassObj(pc)
-	return cl.Create(context.TODO(), pc)
+func createBufferDeployment(cl client.Client, namespace string, pool BufferPoolSpec, memGi, cpuMilli int64) error {
+	dt := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      pool.deploymentName(),
+			Namespace: namespace,
+		},
+	}
+	patchBufferDeployment(dt, pool, memGi, cpuMilli)
+	return cl.Create(context.TODO(), dt)
 }
 
 func patchLabels(meta *v1.ObjectMeta, key, value string) bool {
@@ -146,65 +260,264 @@ func patchLabels(meta *v1.ObjectMeta, key, value string) bool {
 //kind: Deployment
 //apiVersion: apps/v1
 //metadata:
-//  name: autoscaling-buffer
+//  name: ${POOL_DEPLOYMENT_NAME}
 //  namespace: ${NAMESPACE}
 //  labels:
-//    app: autoscaling-buffer
+//    app: ${POOL_DEPLOYMENT_NAME}
 //spec:
-//  replicas: 1
+//  replicas: ${REPLICAS}
 //  selector:
 //    matchLabels:
-//      app: autoscaling-buffer
+//      app: ${POOL_DEPLOYMENT_NAME}
 //  template:
 //    metadata:
 //      labels:
-//        app: autoscaling-buffer
+//        app: ${POOL_DEPLOYMENT_NAME}
 //    spec:
-//      priorityClassName: autoscaling-buffer
+//      priorityClassName: ${POOL_PRIORITY_CLASS_NAME}
 //      terminationGracePeriodSeconds: 0
+//      tolerations: ${POOL_TOLERATIONS}
+//      affinity:
+//        nodeAffinity: # only set when POOL_NODE_SELECTOR_TERM is not empty
+//          requiredDuringSchedulingIgnoredDuringExecution:
+//            nodeSelectorTerms:
+//            - ${POOL_NODE_SELECTOR_TERM}
+//        podAntiAffinity:
+//          requiredDuringSchedulingIgnoredDuringExecution:
+//          - labelSelector:
+//              matchLabels:
+//                app: ${POOL_DEPLOYMENT_NAME}
+//            topologyKey: kubernetes.io/hostname
+//      topologySpreadConstraints:
+//      - maxSkew: 1
+//        topologyKey: kubernetes.io/hostname
+//        whenUnsatisfiable: DoNotSchedule
+//        labelSelector:
+//          matchLabels:
+//            app: ${POOL_DEPLOYMENT_NAME}
 //      containers:
 //      - name: autoscaling-buffer
 //        image: gcr.io/google_containers/pause-amd64:3.0
 //        resources:
 //          requests:
 //            memory: ${MEMORY}
+//            cpu: ${CPU}
 //          limits:
 //            memory: ${MEMORY}
+//            cpu: ${CPU}
 
-func patchBufferDeployment(dt *appsv1.Deployment) bool {
+func patchBufferDeployment(dt *appsv1.Deployment, pool BufferPoolSpec, memGi, cpuMilli int64) bool {
+	appLabel := pool.deploymentName()
 	updated := patchLabels(&dt.ObjectMeta, toolchainv1alpha1.ProviderLabelKey, toolchainv1alpha1.ProviderLabelValue)
-	updated = patchLabels(&dt.ObjectMeta, "app", bufferAppName) || updated
+	updated = patchLabels(&dt.ObjectMeta, "app", appLabel) || updated
 
-	replicas := dt.Spec.Replicas
-	one := int32(1)
-	if replicas == nil || *replicas != one {
-		replicas = &one
+	replicas := pool.replicas()
+	if dt.Spec.Replicas == nil || *dt.Spec.Replicas != replicas {
+		r := replicas
+		dt.Spec.Replicas = &r
+		updated = true
+	}
+	if dt.Spec.Selector == nil || len(dt.Spec.Selector.MatchLabels) != 1 || dt.Spec.Selector.MatchLabels["app"] != appLabel {
+		dt.Spec.Selector = &v1.LabelSelector{MatchLabels: map[string]string{"app": appLabel}}
 		updated = true
 	}
-	if dt.Spec.Selector == nil || dt.Spec.Selector.MatchLabels == nil {
-		if app, found := dt.Spec.Selector.MatchLabels["app"]; !found || app != bufferAppName || len(dt.Spec.Selector.MatchLabels) > 1 {
-			dt.Spec.Selector = &v1.LabelSelector{MatchLabels: map[string]string{"app": bufferAppName}}
-			updated = true
+
+	memory := *resource.NewScaledQuantity(memGi, resource.Giga)
+	cpu := *resource.NewMilliQuantity(cpuMilli, resource.DecimalSI)
+	podLabels := map[string]string{"app": appLabel}
+	topologySpread := []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       "kubernetes.io/hostname",
+			WhenUnsatisfiable: corev1.DoNotSchedule,
+			LabelSelector:     &v1.LabelSelector{MatchLabels: podLabels},
+		},
+	}
+	affinity := &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+				{
+					LabelSelector: &v1.LabelSelector{MatchLabels: podLabels},
+					TopologyKey:   "kubernetes.io/hostname",
+				},
+			},
+		},
+	}
+	if len(pool.NodeSelectorTerm.MatchExpressions) > 0 || len(pool.NodeSelectorTerm.MatchFields) > 0 {
+		affinity.NodeAffinity = &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{pool.NodeSelectorTerm},
+			},
 		}
 	}
+	terminationGracePeriodSeconds := int64(0)
+	wantSpec := corev1.PodSpec{
+		PriorityClassName:             pool.priorityClassName(),
+		TerminationGracePeriodSeconds: &terminationGracePeriodSeconds,
+		Tolerations:                   pool.Tolerations,
+		Affinity:                      affinity,
+		TopologySpreadConstraints:     topologySpread,
+		Containers: []corev1.Container{
+			{
+				Name:  bufferAppName,
+				Image: "gcr.io/google_containers/pause-amd64:3.0",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: memory, corev1.ResourceCPU: cpu},
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: memory, corev1.ResourceCPU: cpu},
+				},
+			},
+		},
+	}
+	if !podSpecsEqual(dt.Spec.Template.Spec, wantSpec) {
+		dt.Spec.Template.ObjectMeta.Labels = podLabels
+		dt.Spec.Template.Spec = wantSpec
+		updated = true
+	}
+
 	return updated
 }
 
-func bufferSizeGi(cl client.Client) (int64, error) {
+// podSpecsEqual compares the parts of the buffer pod spec that EnsureBuffer sets from the pool
+// configuration - priority class, termination grace period, tolerations, topology spread skew/key/
+// whenUnsatisfiable, node affinity, and the container image and resource requests and limits - so
+// unrelated fields set by the cluster (defaulting, service account, etc.) don't cause a perpetual
+// diff. Pod anti-affinity, label selectors and the container name are not compared.
+func podSpecsEqual(a, b corev1.PodSpec) bool {
+	if a.PriorityClassName != b.PriorityClassName {
+		return false
+	}
+	if (a.TerminationGracePeriodSeconds == nil) != (b.TerminationGracePeriodSeconds == nil) {
+		return false
+	}
+	if a.TerminationGracePeriodSeconds != nil && *a.TerminationGracePeriodSeconds != *b.TerminationGracePeriodSeconds {
+		return false
+	}
+	if len(a.Tolerations) != len(b.Tolerations) {
+		return false
+	}
+	for i := range a.Tolerations {
+		if !tolerationsEqual(a.Tolerations[i], b.Tolerations[i]) {
+			return false
+		}
+	}
+	if len(a.TopologySpreadConstraints) != len(b.TopologySpreadConstraints) {
+		return false
+	}
+	for i := range a.TopologySpreadConstraints {
+		if a.TopologySpreadConstraints[i].MaxSkew != b.TopologySpreadConstraints[i].MaxSkew ||
+			a.TopologySpreadConstraints[i].TopologyKey != b.TopologySpreadConstraints[i].TopologyKey ||
+			a.TopologySpreadConstraints[i].WhenUnsatisfiable != b.TopologySpreadConstraints[i].WhenUnsatisfiable {
+			return false
+		}
+	}
+	if (a.Affinity == nil) != (b.Affinity == nil) {
+		return false
+	}
+	if a.Affinity != nil && !nodeAffinityEqual(a.Affinity.NodeAffinity, b.Affinity.NodeAffinity) {
+		return false
+	}
+	if len(a.Containers) != len(b.Containers) || len(b.Containers) != 1 {
+		return false
+	}
+	if a.Containers[0].Image != b.Containers[0].Image {
+		return false
+	}
+	aMem := a.Containers[0].Resources.Requests[corev1.ResourceMemory]
+	bMem := b.Containers[0].Resources.Requests[corev1.ResourceMemory]
+	aCPU := a.Containers[0].Resources.Requests[corev1.ResourceCPU]
+	bCPU := b.Containers[0].Resources.Requests[corev1.ResourceCPU]
+	aMemLimit := a.Containers[0].Resources.Limits[corev1.ResourceMemory]
+	bMemLimit := b.Containers[0].Resources.Limits[corev1.ResourceMemory]
+	aCPULimit := a.Containers[0].Resources.Limits[corev1.ResourceCPU]
+	bCPULimit := b.Containers[0].Resources.Limits[corev1.ResourceCPU]
+	return aMem.Cmp(bMem) == 0 && aCPU.Cmp(bCPU) == 0 &&
+		aMemLimit.Cmp(bMemLimit) == 0 && aCPULimit.Cmp(bCPULimit) == 0
+}
+
+// tolerationsEqual compares two Tolerations by value, including the pointed-to TolerationSeconds
+// rather than the *int64 itself - BufferPoolConfig/BufferPoolSpec rebuild Tolerations fresh on
+// every reconcile, so comparing the pointer would mark the deployment "updated" on every reconcile
+// forever as soon as a pool ever set TolerationSeconds (the normal pattern for NoExecute taints).
+func tolerationsEqual(a, b corev1.Toleration) bool {
+	if a.Key != b.Key || a.Operator != b.Operator || a.Value != b.Value || a.Effect != b.Effect {
+		return false
+	}
+	if (a.TolerationSeconds == nil) != (b.TolerationSeconds == nil) {
+		return false
+	}
+	return a.TolerationSeconds == nil || *a.TolerationSeconds == *b.TolerationSeconds
+}
+
+// nodeAffinityEqual compares the node selector terms two node affinities require, so a pool's
+// node selector change (e.g. switching which label selects its nodes) is detected instead of only
+// whether NodeAffinity is nil.
+func nodeAffinityEqual(a, b *corev1.NodeAffinity) bool {
+	if (a == nil) != (b == nil) {
+		return false
+	}
+	if a == nil {
+		return true
+	}
+	aReq, bReq := a.RequiredDuringSchedulingIgnoredDuringExecution, b.RequiredDuringSchedulingIgnoredDuringExecution
+	if (aReq == nil) != (bReq == nil) {
+		return false
+	}
+	if aReq == nil {
+		return true
+	}
+	return reflect.DeepEqual(aReq.NodeSelectorTerms, bReq.NodeSelectorTerms)
+}
+
+// bufferSize returns the per-replica memory (in Gi) and CPU (in millicores) that the given pool's
+// buffer pods should request. It finds a representative worker node matching the pool's node
+// selector and computes `pool.resourceRatio()` of that node's allocatable memory and CPU.
+//
+// Each replica is sized independently at (close to) the full per-node ratio, not divided across
+// `pool.replicas()`. Topology spread and anti-affinity only guarantee the replicas land on
+// distinct nodes - they say nothing about whether those nodes were already running. What actually
+// forces cluster-autoscaler to provision a new node is a single pod requesting more than the free
+// headroom on every already-running node. Shrinking each replica by 1/replicas would let most of
+// them fit into existing slack instead, so only a fraction of the intended nodes would ever be
+// pre-warmed. Sizing every replica at the full ratio makes each one, on its own, too big to
+// schedule onto a node that isn't already spare capacity, so `pool.replicas()` of them together
+// force that many distinct nodes to be pre-provisioned.
+func bufferSize(cl client.Client, pool BufferPoolSpec) (memGi int64, cpuMilli int64, err error) {
+	// An empty term would match no node at all in nodeaffinity, so it is only turned into a
+	// selector when it has requirements; otherwise every worker node matches.
+	var selector *nodeaffinity.NodeSelector
+	if len(pool.NodeSelectorTerm.MatchExpressions) > 0 || len(pool.NodeSelectorTerm.MatchFields) > 0 {
+		selector, err = nodeaffinity.NewNodeSelector(&corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{pool.NodeSelectorTerm},
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
 	nodes := &corev1.NodeList{}
 	if err := cl.List(context.TODO(), nodes); err != nil {
-		return 0, err
+		return 0, 0, err
 	}
+	ratio := pool.resourceRatio()
 	for _, node := range nodes.Items {
-		if worker(node) {
-			if memoryCapacity, found := node.Status.Allocatable["memory"]; found {
-				allocatableGi := memoryCapacity.ScaledValue(resource.Giga)
-				bufferSizeGi := int64(math.Round(bufferSizeNodeSizeRatio * float64(allocatableGi)))
-				return bufferSizeGi, nil
-			}
+		if !worker(node) || (selector != nil && !selector.Match(&node)) {
+			continue
+		}
+		memoryCapacity, found := node.Status.Allocatable[corev1.ResourceMemory]
+		if !found {
+			continue
+		}
+		cpuCapacity, found := node.Status.Allocatable[corev1.ResourceCPU]
+		if !found {
+			continue
 		}
+		allocatableGi := memoryCapacity.ScaledValue(resource.Giga)
+		allocatableMilliCPU := cpuCapacity.MilliValue()
+		memGi = int64(math.Ceil(ratio * float64(allocatableGi)))
+		cpuMilli = int64(math.Ceil(ratio * float64(allocatableMilliCPU)))
+		return memGi, cpuMilli, nil
 	}
-	return 0, errors.New("unable to obtain allocatable memory of a worker node")
+	return 0, 0, errors.New("unable to find a worker node matching the buffer pool's node selector")
 }
 
 func worker(node corev1.Node) bool {
@@ -0,0 +1,193 @@
+package autoscaler
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/codeready-toolchain/member-operator/pkg/controller/memberstatus"
+)
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	s := runtime.NewScheme()
+	if err := corev1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := appsv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := schedulingv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func workerNode(name string, memoryGi, milliCPU int64) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{memberstatus.LabelNodeRoleWorker: ""},
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceMemory: *resource.NewScaledQuantity(memoryGi, resource.Giga),
+				corev1.ResourceCPU:    *resource.NewMilliQuantity(milliCPU, resource.DecimalSI),
+			},
+		},
+	}
+}
+
+// TestPatchBufferDeployment_SpreadAcrossNodes asserts that each replica is pinned to a distinct
+// worker node via topologySpreadConstraints and pod anti-affinity, as chunk0-1 set out to do.
+func TestPatchBufferDeployment_SpreadAcrossNodes(t *testing.T) {
+	pool := BufferPoolSpec{Replicas: 3}
+	dt := &appsv1.Deployment{}
+	updated := patchBufferDeployment(dt, pool, 1, 100)
+	if !updated {
+		t.Fatal("expected a fresh deployment to be marked as updated")
+	}
+
+	spec := dt.Spec.Template.Spec
+	if len(spec.TopologySpreadConstraints) != 1 {
+		t.Fatalf("expected exactly one topology spread constraint, got %d", len(spec.TopologySpreadConstraints))
+	}
+	tsc := spec.TopologySpreadConstraints[0]
+	if tsc.MaxSkew != 1 || tsc.TopologyKey != "kubernetes.io/hostname" || tsc.WhenUnsatisfiable != corev1.DoNotSchedule {
+		t.Fatalf("unexpected topology spread constraint: %+v", tsc)
+	}
+
+	if spec.Affinity == nil || spec.Affinity.PodAntiAffinity == nil {
+		t.Fatal("expected pod anti-affinity to be set")
+	}
+	terms := spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(terms) != 1 || terms[0].TopologyKey != "kubernetes.io/hostname" {
+		t.Fatalf("unexpected anti-affinity terms: %+v", terms)
+	}
+
+	if *dt.Spec.Replicas != 3 {
+		t.Fatalf("expected 3 replicas, got %d", *dt.Spec.Replicas)
+	}
+}
+
+// TestBufferSize_SizedPerNodeNotDividedByReplicas asserts that each replica is sized at the full
+// per-node ratio regardless of replica count, so the sum of replicas forces that many distinct
+// nodes to be pre-provisioned instead of fitting into the slack of already-running nodes.
+func TestBufferSize_SizedPerNodeNotDividedByReplicas(t *testing.T) {
+	cl := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(workerNode("node-1", 100, 100_000)).Build()
+
+	for _, replicas := range []int32{1, 5} {
+		pool := BufferPoolSpec{Replicas: replicas}
+		memGi, cpuMilli, err := bufferSize(cl, pool)
+		if err != nil {
+			t.Fatalf("replicas=%d: %v", replicas, err)
+		}
+		if memGi != 80 {
+			t.Errorf("replicas=%d: expected memGi 80 (80%% of one node), got %d", replicas, memGi)
+		}
+		if cpuMilli != 80_000 {
+			t.Errorf("replicas=%d: expected cpuMilli 80000 (80%% of one node), got %d", replicas, cpuMilli)
+		}
+	}
+}
+
+// TestBufferSize_RecomputesOnNodeSizeChange asserts that resizing the representative node changes
+// the computed per-replica request, so a cluster scaled to bigger machines gets a bigger buffer.
+func TestBufferSize_RecomputesOnNodeSizeChange(t *testing.T) {
+	small := workerNode("node-1", 100, 100_000)
+	cl := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(small).Build()
+
+	pool := BufferPoolSpec{Replicas: 2}
+	memGi, _, err := bufferSize(cl, pool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if memGi != 80 {
+		t.Fatalf("expected memGi 80 for a 100Gi node, got %d", memGi)
+	}
+
+	big := small.DeepCopy()
+	big.Status.Allocatable[corev1.ResourceMemory] = *resource.NewScaledQuantity(200, resource.Giga)
+	if err := cl.Status().Update(context.TODO(), big); err != nil {
+		t.Fatal(err)
+	}
+
+	memGi, _, err = bufferSize(cl, pool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if memGi != 160 {
+		t.Fatalf("expected memGi 160 after the node doubled in size, got %d", memGi)
+	}
+}
+
+// TestPatchBufferDeployment_DetectsNodeSelectorChange asserts that changing a pool's node
+// selector is detected as an update, not just whether NodeAffinity is nil vs non-nil - otherwise
+// patchBufferDeployment would never roll a Deployment whose pool was repointed at a new node pool.
+func TestPatchBufferDeployment_DetectsNodeSelectorChange(t *testing.T) {
+	gpuTerm := corev1.NodeSelectorTerm{
+		MatchExpressions: []corev1.NodeSelectorRequirement{
+			{Key: "node-role/gpu", Operator: corev1.NodeSelectorOpExists},
+		},
+	}
+	memTerm := corev1.NodeSelectorTerm{
+		MatchExpressions: []corev1.NodeSelectorRequirement{
+			{Key: "node-role/memory-optimized", Operator: corev1.NodeSelectorOpExists},
+		},
+	}
+
+	dt := &appsv1.Deployment{}
+	if !patchBufferDeployment(dt, BufferPoolSpec{NodeSelectorTerm: gpuTerm}, 1, 100) {
+		t.Fatal("expected a fresh deployment to be marked as updated")
+	}
+
+	if updated := patchBufferDeployment(dt, BufferPoolSpec{NodeSelectorTerm: gpuTerm}, 1, 100); updated {
+		t.Fatal("expected re-applying the same node selector to be a no-op")
+	}
+
+	if updated := patchBufferDeployment(dt, BufferPoolSpec{NodeSelectorTerm: memTerm}, 1, 100); !updated {
+		t.Fatal("expected changing the node selector to be detected as an update")
+	}
+}
+
+// TestPatchBufferDeployment_TolerationSecondsByValue asserts that re-applying an identical
+// toleration with TolerationSeconds set is a no-op, i.e. the comparison dereferences
+// TolerationSeconds instead of comparing the *int64 pointers - which would always differ since
+// BufferPoolSpec.Tolerations is rebuilt fresh on every reconcile.
+func TestPatchBufferDeployment_TolerationSecondsByValue(t *testing.T) {
+	seconds := int64(300)
+	toleration := corev1.Toleration{
+		Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "gpu",
+		Effect: corev1.TaintEffectNoExecute, TolerationSeconds: &seconds,
+	}
+	pool := BufferPoolSpec{Tolerations: []corev1.Toleration{toleration}}
+
+	dt := &appsv1.Deployment{}
+	if !patchBufferDeployment(dt, pool, 1, 100) {
+		t.Fatal("expected a fresh deployment to be marked as updated")
+	}
+
+	rebuiltSeconds := int64(300)
+	rebuiltPool := BufferPoolSpec{Tolerations: []corev1.Toleration{{
+		Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "gpu",
+		Effect: corev1.TaintEffectNoExecute, TolerationSeconds: &rebuiltSeconds,
+	}}}
+	if updated := patchBufferDeployment(dt, rebuiltPool, 1, 100); updated {
+		t.Fatal("expected an equal-by-value but distinct *TolerationSeconds pointer to be a no-op")
+	}
+
+	changedSeconds := int64(60)
+	changedPool := BufferPoolSpec{Tolerations: []corev1.Toleration{{
+		Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "gpu",
+		Effect: corev1.TaintEffectNoExecute, TolerationSeconds: &changedSeconds,
+	}}}
+	if updated := patchBufferDeployment(dt, changedPool, 1, 100); !updated {
+		t.Fatal("expected a changed TolerationSeconds value to be detected as an update")
+	}
+}
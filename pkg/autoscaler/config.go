@@ -0,0 +1,82 @@
+package autoscaler
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// BufferPoolConfig is the admin-facing, serializable shape of a buffer pool, meant to be stored as
+// an element of the member-operator config CR's Spec.Autoscaler.BufferPools field. It mirrors
+// BufferPoolSpec but describes the node selector as plain labels instead of embedding
+// corev1.NodeSelectorTerm, so the CRD schema doesn't need to expose the full Kubernetes node
+// affinity API to admins who only want to match a pool by its labels.
+type BufferPoolConfig struct {
+	// Name identifies the pool. See BufferPoolSpec.Name.
+	Name string
+
+	// NodeSelectorLabels selects the worker nodes that belong to this pool: every label must be
+	// present with the given value. Empty matches every worker node.
+	NodeSelectorLabels map[string]string
+
+	// Tolerations are copied onto the buffer pod for this pool. See BufferPoolSpec.Tolerations.
+	Tolerations []corev1.Toleration
+
+	// Replicas is the number of buffer pods to spread across this pool's nodes. See
+	// BufferPoolSpec.Replicas.
+	Replicas int32
+
+	// ResourceRatio overrides bufferSizeNodeSizeRatio for this pool. See
+	// BufferPoolSpec.ResourceRatio.
+	ResourceRatio float64
+
+	// PriorityClassValue overrides defaultPriorityClassValue for this pool. See
+	// BufferPoolSpec.PriorityClassValue.
+	PriorityClassValue int32
+}
+
+// BufferPoolSpecsFromConfig converts the admin-facing BufferPoolConfig list maintained on the
+// member-operator config CR into the BufferPoolSpec list EnsureBuffer, PlanBuffer, and the
+// Node-change Reconciler expect.
+func BufferPoolSpecsFromConfig(pools []BufferPoolConfig) []BufferPoolSpec {
+	specs := make([]BufferPoolSpec, 0, len(pools))
+	for _, pool := range pools {
+		specs = append(specs, pool.toBufferPoolSpec())
+	}
+	return specs
+}
+
+func (c BufferPoolConfig) toBufferPoolSpec() BufferPoolSpec {
+	return BufferPoolSpec{
+		Name:               c.Name,
+		NodeSelectorTerm:   nodeSelectorTermFromLabels(c.NodeSelectorLabels),
+		Tolerations:        c.Tolerations,
+		Replicas:           c.Replicas,
+		ResourceRatio:      c.ResourceRatio,
+		PriorityClassValue: c.PriorityClassValue,
+	}
+}
+
+// nodeSelectorTermFromLabels turns a set of required labels into match expressions, sorted by key
+// so the result - and therefore the Deployment patchBufferDeployment computes from it - is
+// deterministic across reconciles regardless of Go's randomized map iteration order.
+func nodeSelectorTermFromLabels(labels map[string]string) corev1.NodeSelectorTerm {
+	if len(labels) == 0 {
+		return corev1.NodeSelectorTerm{}
+	}
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	exprs := make([]corev1.NodeSelectorRequirement, 0, len(keys))
+	for _, key := range keys {
+		exprs = append(exprs, corev1.NodeSelectorRequirement{
+			Key:      key,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{labels[key]},
+		})
+	}
+	return corev1.NodeSelectorTerm{MatchExpressions: exprs}
+}
@@ -0,0 +1,71 @@
+package autoscaler
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestBufferPoolSpecsFromConfig(t *testing.T) {
+	configs := []BufferPoolConfig{
+		{
+			Name:               "gpu",
+			NodeSelectorLabels: map[string]string{"node-role/gpu": "true", "zone": "us-east-1a"},
+			Replicas:           2,
+			ResourceRatio:      0.5,
+			PriorityClassValue: -50,
+		},
+	}
+
+	specs := BufferPoolSpecsFromConfig(configs)
+	if len(specs) != 1 {
+		t.Fatalf("expected 1 spec, got %d", len(specs))
+	}
+
+	spec := specs[0]
+	if spec.Name != "gpu" || spec.Replicas != 2 || spec.ResourceRatio != 0.5 || spec.PriorityClassValue != -50 {
+		t.Fatalf("unexpected conversion of scalar fields: %+v", spec)
+	}
+
+	want := corev1.NodeSelectorTerm{
+		MatchExpressions: []corev1.NodeSelectorRequirement{
+			{Key: "node-role/gpu", Operator: corev1.NodeSelectorOpIn, Values: []string{"true"}},
+			{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"us-east-1a"}},
+		},
+	}
+	if len(spec.NodeSelectorTerm.MatchExpressions) != len(want.MatchExpressions) {
+		t.Fatalf("expected %d match expressions, got %d", len(want.MatchExpressions), len(spec.NodeSelectorTerm.MatchExpressions))
+	}
+	for i := range want.MatchExpressions {
+		if !reflect.DeepEqual(spec.NodeSelectorTerm.MatchExpressions[i], want.MatchExpressions[i]) {
+			t.Fatalf("match expression %d: expected %+v, got %+v", i, want.MatchExpressions[i], spec.NodeSelectorTerm.MatchExpressions[i])
+		}
+	}
+}
+
+// TestBufferPoolSpecsFromConfig_DeterministicOrder asserts the conversion sorts labels by key, so
+// repeated calls (as happen on every reconcile) produce the same NodeSelectorTerm and don't cause
+// patchBufferDeployment to see a spurious diff from Go's randomized map iteration order.
+func TestBufferPoolSpecsFromConfig_DeterministicOrder(t *testing.T) {
+	labels := map[string]string{"c": "3", "a": "1", "b": "2"}
+	first := BufferPoolSpecsFromConfig([]BufferPoolConfig{{NodeSelectorLabels: labels}})[0]
+	for i := 0; i < 10; i++ {
+		got := BufferPoolSpecsFromConfig([]BufferPoolConfig{{NodeSelectorLabels: labels}})[0]
+		if !nodeSelectorTermEqual(first.NodeSelectorTerm, got.NodeSelectorTerm) {
+			t.Fatalf("expected deterministic NodeSelectorTerm, got %+v vs %+v", first.NodeSelectorTerm, got.NodeSelectorTerm)
+		}
+	}
+}
+
+func nodeSelectorTermEqual(a, b corev1.NodeSelectorTerm) bool {
+	if len(a.MatchExpressions) != len(b.MatchExpressions) {
+		return false
+	}
+	for i := range a.MatchExpressions {
+		if !reflect.DeepEqual(a.MatchExpressions[i], b.MatchExpressions[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,187 @@
+package autoscaler
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BufferPlan is the computed effect of reconciling a set of buffer pools, without actually
+// creating or updating anything in the cluster.
+type BufferPlan struct {
+	Pools []BufferPoolPlan
+}
+
+// BufferPoolPlan is the plan for a single BufferPoolSpec.
+type BufferPoolPlan struct {
+	DeploymentName     string
+	PriorityClassName  string
+	PriorityClassValue int32
+	Replicas           int32
+	MemoryPerReplica   resource.Quantity
+	CPUPerReplica      resource.Quantity
+	// TargetNodes are the worker nodes currently matching the pool's node selector.
+	TargetNodes []string
+	// DeploymentDiff describes what EnsureBuffer would do to the Deployment: "create", "up to
+	// date", or a summary of what would change.
+	DeploymentDiff string
+	// PriorityClassDiff is the same, for the PriorityClass.
+	PriorityClassDiff string
+}
+
+// PlanBuffer computes the BufferPlan for the given pools - replica counts, per-replica CPU/memory,
+// target nodes, priority class values, and a diff against any existing Deployment/PriorityClass -
+// without calling Create or Update. It lets operators validate the effect of a config change
+// (bufferSizeNodeSizeRatio, pool selectors, priority values) before EnsureBuffer applies it.
+func PlanBuffer(cl client.Client, namespace string, pools []BufferPoolSpec) (BufferPlan, error) {
+	if len(pools) == 0 {
+		pools = []BufferPoolSpec{{}}
+	}
+
+	plan := BufferPlan{}
+	for _, pool := range pools {
+		poolPlan, err := planPool(cl, namespace, pool)
+		if err != nil {
+			return BufferPlan{}, fmt.Errorf("failed to plan buffer pool %q: %w", pool.deploymentName(), err)
+		}
+		plan.Pools = append(plan.Pools, poolPlan)
+	}
+	return plan, nil
+}
+
+func planPool(cl client.Client, namespace string, pool BufferPoolSpec) (BufferPoolPlan, error) {
+	memGi, cpuMilli, err := bufferSize(cl, pool)
+	if err != nil {
+		return BufferPoolPlan{}, err
+	}
+
+	targetNodes, err := targetNodeNames(cl, pool)
+	if err != nil {
+		return BufferPoolPlan{}, err
+	}
+
+	deploymentDiff, err := planDeploymentDiff(cl, namespace, pool, memGi, cpuMilli)
+	if err != nil {
+		return BufferPoolPlan{}, err
+	}
+
+	priorityClassDiff, err := planPriorityClassDiff(cl, pool)
+	if err != nil {
+		return BufferPoolPlan{}, err
+	}
+
+	return BufferPoolPlan{
+		DeploymentName:     pool.deploymentName(),
+		PriorityClassName:  pool.priorityClassName(),
+		PriorityClassValue: pool.priorityClassValue(),
+		Replicas:           pool.replicas(),
+		MemoryPerReplica:   *resource.NewScaledQuantity(memGi, resource.Giga),
+		CPUPerReplica:      *resource.NewMilliQuantity(cpuMilli, resource.DecimalSI),
+		TargetNodes:        targetNodes,
+		DeploymentDiff:     deploymentDiff,
+		PriorityClassDiff:  priorityClassDiff,
+	}, nil
+}
+
+func planDeploymentDiff(cl client.Client, namespace string, pool BufferPoolSpec, memGi, cpuMilli int64) (string, error) {
+	dt := &appsv1.Deployment{}
+	err := cl.Get(context.TODO(), types.NamespacedName{Name: pool.deploymentName(), Namespace: namespace}, dt)
+	if k8serrors.IsNotFound(err) {
+		return "create", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	before := dt.DeepCopy()
+	if !patchBufferDeployment(dt, pool, memGi, cpuMilli) {
+		return "up to date", nil
+	}
+	return diffDeployments(before, dt)
+}
+
+func planPriorityClassDiff(cl client.Client, pool BufferPoolSpec) (string, error) {
+	pc := &schedulingv1.PriorityClass{}
+	err := cl.Get(context.TODO(), types.NamespacedName{Name: pool.priorityClassName()}, pc)
+	if k8serrors.IsNotFound(err) {
+		return "create", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if !patchPriorityClassObj(pc, pool) {
+		return "up to date", nil
+	}
+	return "update priority class value/description/labels", nil
+}
+
+// diffDeployments summarises the fields EnsureBuffer would change, so operators can see the
+// effect of a config change at a glance instead of a full object diff. It errors rather than
+// panicking if either Deployment has no containers - which happens if PlanBuffer is pointed at a
+// pre-existing Deployment that isn't actually one of ours.
+func diffDeployments(before, after *appsv1.Deployment) (string, error) {
+	if len(before.Spec.Template.Spec.Containers) == 0 || len(after.Spec.Template.Spec.Containers) == 0 {
+		return "", fmt.Errorf("deployment %q has no containers, refusing to diff", before.Name)
+	}
+
+	diff := ""
+	if before.Spec.Replicas == nil || after.Spec.Replicas == nil || *before.Spec.Replicas != *after.Spec.Replicas {
+		diff += fmt.Sprintf("replicas %v -> %v; ", quantityOrNil(before.Spec.Replicas), quantityOrNil(after.Spec.Replicas))
+	}
+	beforeMem := before.Spec.Template.Spec.Containers[0].Resources.Requests[corev1.ResourceMemory]
+	afterMem := after.Spec.Template.Spec.Containers[0].Resources.Requests[corev1.ResourceMemory]
+	if beforeMem.Cmp(afterMem) != 0 {
+		diff += fmt.Sprintf("memory %s -> %s; ", beforeMem.String(), afterMem.String())
+	}
+	beforeCPU := before.Spec.Template.Spec.Containers[0].Resources.Requests[corev1.ResourceCPU]
+	afterCPU := after.Spec.Template.Spec.Containers[0].Resources.Requests[corev1.ResourceCPU]
+	if beforeCPU.Cmp(afterCPU) != 0 {
+		diff += fmt.Sprintf("cpu %s -> %s; ", beforeCPU.String(), afterCPU.String())
+	}
+	if diff == "" {
+		return "update labels/spread/affinity", nil
+	}
+	return diff, nil
+}
+
+func quantityOrNil(r *int32) string {
+	if r == nil {
+		return "unset"
+	}
+	return fmt.Sprintf("%d", *r)
+}
+
+// targetNodeNames returns the names of the worker nodes currently matching the pool's node
+// selector, in the order returned by the API.
+func targetNodeNames(cl client.Client, pool BufferPoolSpec) ([]string, error) {
+	// As in bufferSize, an empty term matches every worker node.
+	var selector *nodeaffinity.NodeSelector
+	if len(pool.NodeSelectorTerm.MatchExpressions) > 0 || len(pool.NodeSelectorTerm.MatchFields) > 0 {
+		var err error
+		selector, err = nodeaffinity.NewNodeSelector(&corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{pool.NodeSelectorTerm},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := cl.List(context.TODO(), nodes); err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, node := range nodes.Items {
+		if worker(node) && (selector == nil || selector.Match(&node)) {
+			names = append(names, node.Name)
+		}
+	}
+	return names, nil
+}
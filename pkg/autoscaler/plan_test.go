@@ -0,0 +1,179 @@
+package autoscaler
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/codeready-toolchain/member-operator/pkg/controller/memberstatus"
+)
+
+// bufferDeployment builds a Deployment as patchBufferDeployment would leave it for the default
+// pool, for tests that need an already-existing object to diff against.
+func bufferDeployment(replicas int32, memGi, cpuMilli int64) *appsv1.Deployment {
+	dt := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: bufferAppName}}
+	patchBufferDeployment(dt, BufferPoolSpec{Replicas: replicas}, memGi, cpuMilli)
+	return dt
+}
+
+func TestPlanDeploymentDiff_Create(t *testing.T) {
+	cl := fake.NewClientBuilder().WithScheme(newScheme(t)).Build()
+
+	diff, err := planDeploymentDiff(cl, "ns", BufferPoolSpec{}, 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "create" {
+		t.Fatalf("expected %q, got %q", "create", diff)
+	}
+}
+
+func TestPlanDeploymentDiff_UpToDate(t *testing.T) {
+	pool := BufferPoolSpec{}
+	dt := bufferDeployment(1, 1, 100)
+	dt.Namespace = "ns"
+	cl := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(dt).Build()
+
+	diff, err := planDeploymentDiff(cl, "ns", pool, 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "up to date" {
+		t.Fatalf("expected %q, got %q", "up to date", diff)
+	}
+}
+
+func TestPlanDeploymentDiff_ReplicasChanged(t *testing.T) {
+	pool := BufferPoolSpec{Replicas: 3}
+	dt := bufferDeployment(1, 1, 100)
+	dt.Namespace = "ns"
+	cl := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(dt).Build()
+
+	diff, err := planDeploymentDiff(cl, "ns", pool, 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(diff, "replicas 1 -> 3") {
+		t.Fatalf("expected a replicas diff, got %q", diff)
+	}
+}
+
+func TestPlanDeploymentDiff_MemoryChanged(t *testing.T) {
+	pool := BufferPoolSpec{}
+	dt := bufferDeployment(1, 1, 100)
+	dt.Namespace = "ns"
+	cl := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(dt).Build()
+
+	diff, err := planDeploymentDiff(cl, "ns", pool, 2, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(diff, "memory") {
+		t.Fatalf("expected a memory diff, got %q", diff)
+	}
+}
+
+func TestPlanDeploymentDiff_CPUChanged(t *testing.T) {
+	pool := BufferPoolSpec{}
+	dt := bufferDeployment(1, 1, 100)
+	dt.Namespace = "ns"
+	cl := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(dt).Build()
+
+	diff, err := planDeploymentDiff(cl, "ns", pool, 1, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(diff, "cpu") {
+		t.Fatalf("expected a cpu diff, got %q", diff)
+	}
+}
+
+// TestDiffDeployments_NoContainersErrors asserts that a pre-existing Deployment that isn't
+// actually one of ours (e.g. zero containers) produces an error instead of panicking on an
+// out-of-range Containers[0] access.
+func TestDiffDeployments_NoContainersErrors(t *testing.T) {
+	before := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "not-ours"}}
+	after := bufferDeployment(1, 1, 100)
+
+	if _, err := diffDeployments(before, after); err == nil {
+		t.Fatal("expected an error for a before-Deployment with no containers")
+	}
+}
+
+func TestDiffDeployments_UpdateWithoutResourceOrReplicaChange(t *testing.T) {
+	before := bufferDeployment(1, 1, 100)
+	after := before.DeepCopy()
+	after.Spec.Template.Labels["extra"] = "label"
+
+	diff, err := diffDeployments(before, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "update labels/spread/affinity" {
+		t.Fatalf("expected the fallback message, got %q", diff)
+	}
+}
+
+func TestTargetNodeNames(t *testing.T) {
+	matching := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{memberstatus.LabelNodeRoleWorker: ""}}}
+	cl := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(matching).Build()
+
+	names, err := targetNodeNames(cl, BufferPoolSpec{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 1 || names[0] != "node-1" {
+		t.Fatalf("expected [node-1], got %v", names)
+	}
+}
+
+func TestPlanPriorityClassDiff_Create(t *testing.T) {
+	cl := fake.NewClientBuilder().WithScheme(newScheme(t)).Build()
+
+	diff, err := planPriorityClassDiff(cl, BufferPoolSpec{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "create" {
+		t.Fatalf("expected %q, got %q", "create", diff)
+	}
+}
+
+func TestQuantityOrNil(t *testing.T) {
+	if got := quantityOrNil(nil); got != "unset" {
+		t.Fatalf("expected %q, got %q", "unset", got)
+	}
+	r := int32(3)
+	if got := quantityOrNil(&r); got != "3" {
+		t.Fatalf("expected %q, got %q", "3", got)
+	}
+}
+
+func TestPlanBuffer_DefaultsToSinglePoolWhenEmpty(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{memberstatus.LabelNodeRoleWorker: ""}},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceMemory: *resource.NewScaledQuantity(100, resource.Giga),
+				corev1.ResourceCPU:    *resource.NewMilliQuantity(100_000, resource.DecimalSI),
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(node).Build()
+
+	plan, err := PlanBuffer(cl, "ns", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Pools) != 1 || plan.Pools[0].DeploymentName != bufferAppName {
+		t.Fatalf("expected a single default pool, got %+v", plan.Pools)
+	}
+	if plan.Pools[0].DeploymentDiff != "create" {
+		t.Fatalf("expected the deployment diff to be %q, got %q", "create", plan.Pools[0].DeploymentDiff)
+	}
+}
@@ -0,0 +1,81 @@
+package autoscaler
+
+import (
+	"context"
+
+	"github.com/codeready-toolchain/member-operator/pkg/autoscaler"
+	"github.com/codeready-toolchain/member-operator/pkg/controller/memberstatus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Reconciler re-runs autoscaler.EnsureBuffer whenever a worker Node's shape changes, so the buffer
+// deployments stay sized to the cluster's current nodes instead of only being fixed up on the
+// operator's regular config reconcile.
+type Reconciler struct {
+	Client    client.Client
+	Namespace string
+
+	// Pools returns the buffer pool configuration to reconcile. It is injected rather than read
+	// from the member-operator config directly, so this controller doesn't need to depend on the
+	// config package.
+	Pools func() []autoscaler.BufferPoolSpec
+}
+
+// Reconcile ignores which Node triggered it - a changed node shape can affect the representative
+// node any pool picks, so every pool is always re-ensured. Once the buffer is re-ensured, it
+// recomputes the AutoscalingBufferReady MemberStatus condition, so a Node change that leaves a
+// buffer pod unschedulable is reflected there without waiting for the next config reconcile.
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("node allocatable changed, re-ensuring autoscaling buffer", "node", request.Name)
+
+	pools := r.Pools()
+	if err := autoscaler.EnsureBuffer(r.Client, r.Namespace, pools); err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := memberstatus.UpdateAutoscalingBufferCondition(r.Client, r.Namespace, autoscaler.BufferDeploymentNames(pools)); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager, watching Nodes and reconciling on Node
+// creates and deletes and on changes to a Node's allocatable CPU/memory. The predicate does not
+// check whether the Node is a worker.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Node{}).
+		WithEventFilter(nodeAllocatableChangedPredicate()).
+		Complete(r)
+}
+
+// nodeAllocatableChangedPredicate lets Node creates/deletes and allocatable CPU/memory changes
+// through, so a cluster scaled to bigger machines or a replaced node pool triggers a re-size, but
+// unrelated Node updates (e.g. heartbeat status) don't churn the buffer deployments.
+func nodeAllocatableChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return true },
+		DeleteFunc:  func(event.DeleteEvent) bool { return true },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, ok := e.ObjectOld.(*corev1.Node)
+			if !ok {
+				return true
+			}
+			newNode, ok := e.ObjectNew.(*corev1.Node)
+			if !ok {
+				return true
+			}
+			return !equality.Semantic.DeepEqual(oldNode.Status.Allocatable[corev1.ResourceMemory], newNode.Status.Allocatable[corev1.ResourceMemory]) ||
+				!equality.Semantic.DeepEqual(oldNode.Status.Allocatable[corev1.ResourceCPU], newNode.Status.Allocatable[corev1.ResourceCPU])
+		},
+	}
+}
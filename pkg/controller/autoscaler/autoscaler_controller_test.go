@@ -0,0 +1,91 @@
+package autoscaler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/codeready-toolchain/member-operator/pkg/autoscaler"
+	"github.com/codeready-toolchain/member-operator/pkg/controller/memberstatus"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/pkg/apis/toolchain/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const testNamespace = "toolchain-member-operator"
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	s := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{
+		corev1.AddToScheme,
+		appsv1.AddToScheme,
+		schedulingv1.AddToScheme,
+		toolchainv1alpha1.AddToScheme,
+	} {
+		if err := add(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return s
+}
+
+// TestReconcile_UpdatesAutoscalingBufferReadyCondition asserts that re-ensuring the buffer on a
+// Node change also recomputes AutoscalingBufferReady on the MemberStatus singleton, so the buffer
+// deployment's readiness doesn't sit unreachable until the next config reconcile.
+func TestReconcile_UpdatesAutoscalingBufferReadyCondition(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-1",
+			Labels: map[string]string{memberstatus.LabelNodeRoleWorker: ""},
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceMemory: *resource.NewScaledQuantity(100, resource.Giga),
+				corev1.ResourceCPU:    *resource.NewMilliQuantity(100_000, resource.DecimalSI),
+			},
+		},
+	}
+	ms := &toolchainv1alpha1.MemberStatus{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      memberstatus.MemberStatusName,
+			Namespace: testNamespace,
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(node, ms).WithStatusSubresource(ms).Build()
+
+	r := &Reconciler{
+		Client:    cl,
+		Namespace: testNamespace,
+		Pools:     func() []autoscaler.BufferPoolSpec { return nil },
+	}
+
+	if _, err := r.Reconcile(context.TODO(), reconcile.Request{}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := &toolchainv1alpha1.MemberStatus{}
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: memberstatus.MemberStatusName, Namespace: testNamespace}, got); err != nil {
+		t.Fatal(err)
+	}
+
+	var found bool
+	for _, cond := range got.Status.Conditions {
+		if cond.Type == memberstatus.ConditionTypeAutoscalingBufferReady {
+			found = true
+			if cond.Reason != memberstatus.ReasonAutoscalingBufferNotReady {
+				t.Fatalf("expected reason %q for a just-created deployment, got %q", memberstatus.ReasonAutoscalingBufferNotReady, cond.Reason)
+			}
+		}
+	}
+	if !found {
+		t.Fatal("expected AutoscalingBufferReady condition to be set on the MemberStatus")
+	}
+}
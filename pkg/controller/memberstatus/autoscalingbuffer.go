@@ -0,0 +1,144 @@
+package memberstatus
+
+import (
+	"context"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/pkg/apis/toolchain/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ConditionTypeAutoscalingBufferReady reports whether the autoscaling buffer deployment(s)
+	// are fully rolled out, i.e. whether the pre-provisioned headroom they create is actually
+	// online for the cluster-autoscaler to rely on.
+	ConditionTypeAutoscalingBufferReady toolchainv1alpha1.ConditionType = "AutoscalingBufferReady"
+
+	ReasonAutoscalingBufferReady      = "BufferReady"
+	ReasonAutoscalingBufferNotReady   = "BufferNotReady"
+	ReasonAutoscalingBufferNotCreated = "BufferNotCreated"
+	// ReasonAutoscalingBufferPodPending is used when a buffer pod can't be scheduled - the exact
+	// signal the cluster-autoscaler needs in order to add a node.
+	ReasonAutoscalingBufferPodPending = "BufferPodPending"
+
+	// MemberStatusName is the name of the singleton MemberStatus resource that aggregates
+	// condition reporting for this member cluster.
+	MemberStatusName = "toolchain-member-status"
+)
+
+// getAutoscalingBufferReadyCondition reports whether every autoscaling buffer Deployment named in
+// deploymentNames is fully rolled out, using the same readiness semantics Helm uses for
+// `helm install --wait`: a Deployment is ready once the controller has observed the latest spec
+// (`status.observedGeneration >= metadata.generation`) and has rolled every replica out
+// (`updatedReplicas == spec.replicas`) and made it available (`availableReplicas == spec.replicas`).
+func getAutoscalingBufferReadyCondition(cl client.Client, namespace string, deploymentNames []string) toolchainv1alpha1.Condition {
+	for _, name := range deploymentNames {
+		dt := &appsv1.Deployment{}
+		if err := cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, dt); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return autoscalingBufferNotReadyCondition(ReasonAutoscalingBufferNotCreated, "buffer deployment "+name+" is not created yet")
+			}
+			return autoscalingBufferNotReadyCondition(ReasonAutoscalingBufferNotReady, err.Error())
+		}
+		cond, err := deploymentReadyCondition(cl, namespace, dt)
+		if err != nil {
+			return autoscalingBufferNotReadyCondition(ReasonAutoscalingBufferNotReady, err.Error())
+		}
+		if cond.Status != corev1.ConditionTrue {
+			return cond
+		}
+	}
+	return toolchainv1alpha1.Condition{
+		Type:   ConditionTypeAutoscalingBufferReady,
+		Status: corev1.ConditionTrue,
+		Reason: ReasonAutoscalingBufferReady,
+	}
+}
+
+// UpdateAutoscalingBufferCondition recomputes the AutoscalingBufferReady condition for the given
+// buffer deployments and sets it on the singleton MemberStatus resource, so callers that trigger a
+// buffer reconcile (e.g. the autoscaler controller's Node watcher) don't need to know the
+// condition's readiness semantics or how MemberStatus stores its conditions.
+func UpdateAutoscalingBufferCondition(cl client.Client, namespace string, deploymentNames []string) error {
+	ms := &toolchainv1alpha1.MemberStatus{}
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: MemberStatusName, Namespace: namespace}, ms); err != nil {
+		return err
+	}
+
+	cond := getAutoscalingBufferReadyCondition(cl, namespace, deploymentNames)
+	ms.Status.Conditions = addOrUpdateCondition(ms.Status.Conditions, cond)
+	return cl.Status().Update(context.TODO(), ms)
+}
+
+func addOrUpdateCondition(conditions []toolchainv1alpha1.Condition, cond toolchainv1alpha1.Condition) []toolchainv1alpha1.Condition {
+	for i := range conditions {
+		if conditions[i].Type == cond.Type {
+			conditions[i] = cond
+			return conditions
+		}
+	}
+	return append(conditions, cond)
+}
+
+func deploymentReadyCondition(cl client.Client, namespace string, dt *appsv1.Deployment) (toolchainv1alpha1.Condition, error) {
+	pending, message, err := bufferPodPending(cl, namespace, dt)
+	if err != nil {
+		return toolchainv1alpha1.Condition{}, err
+	}
+	if pending {
+		return autoscalingBufferNotReadyCondition(ReasonAutoscalingBufferPodPending, message), nil
+	}
+
+	wantReplicas := int32(1)
+	if dt.Spec.Replicas != nil {
+		wantReplicas = *dt.Spec.Replicas
+	}
+	if dt.Status.ObservedGeneration < dt.Generation {
+		return autoscalingBufferNotReadyCondition(ReasonAutoscalingBufferNotReady, "waiting for the deployment controller to observe the latest spec"), nil
+	}
+	if dt.Status.UpdatedReplicas != wantReplicas {
+		return autoscalingBufferNotReadyCondition(ReasonAutoscalingBufferNotReady, "not all replicas have been updated yet"), nil
+	}
+	if dt.Status.AvailableReplicas != wantReplicas {
+		return autoscalingBufferNotReadyCondition(ReasonAutoscalingBufferNotReady, "not all replicas are available yet"), nil
+	}
+
+	return toolchainv1alpha1.Condition{
+		Type:   ConditionTypeAutoscalingBufferReady,
+		Status: corev1.ConditionTrue,
+		Reason: ReasonAutoscalingBufferReady,
+	}, nil
+}
+
+// bufferPodPending reports whether the deployment has a pod the scheduler could not place.
+// appsv1.DeploymentReplicaFailure only fires when the ReplicaSet controller fails to create or
+// delete a pod (e.g. quota errors) - a pod that was created fine and then sits Pending because it
+// doesn't fit anywhere is never reflected there, so the pods themselves have to be checked.
+func bufferPodPending(cl client.Client, namespace string, dt *appsv1.Deployment) (bool, string, error) {
+	appLabel := dt.Labels["app"]
+	pods := &corev1.PodList{}
+	if err := cl.List(context.TODO(), pods, client.InNamespace(namespace), client.MatchingLabels{"app": appLabel}); err != nil {
+		return false, "", err
+	}
+	for _, pod := range pods.Items {
+		for _, c := range pod.Status.Conditions {
+			if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse && c.Reason == corev1.PodReasonUnschedulable {
+				return true, c.Message, nil
+			}
+		}
+	}
+	return false, "", nil
+}
+
+func autoscalingBufferNotReadyCondition(reason, message string) toolchainv1alpha1.Condition {
+	return toolchainv1alpha1.Condition{
+		Type:    ConditionTypeAutoscalingBufferReady,
+		Status:  corev1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	}
+}
@@ -0,0 +1,125 @@
+package memberstatus
+
+import (
+	"context"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/pkg/apis/toolchain/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testNamespace = "toolchain-member-operator"
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	s := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{
+		corev1.AddToScheme,
+		appsv1.AddToScheme,
+		toolchainv1alpha1.AddToScheme,
+	} {
+		if err := add(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return s
+}
+
+func readyDeployment(name string, replicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Namespace:  testNamespace,
+			Labels:     map[string]string{"app": name},
+			Generation: 1,
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			UpdatedReplicas:    replicas,
+			AvailableReplicas:  replicas,
+		},
+	}
+}
+
+func unschedulablePod(name, appLabel string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels:    map[string]string{"app": appLabel},
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{
+				{
+					Type:    corev1.PodScheduled,
+					Status:  corev1.ConditionFalse,
+					Reason:  corev1.PodReasonUnschedulable,
+					Message: "0/3 nodes are available: insufficient memory",
+				},
+			},
+		},
+	}
+}
+
+// TestBufferPodPending_DetectsUnschedulablePod asserts that a pod sitting Pending because the
+// scheduler can't place it is detected via its own PodScheduled condition, not the Deployment's
+// DeploymentReplicaFailure condition (which only covers the ReplicaSet failing to create/delete a
+// pod, never a pod that was created fine and then can't be scheduled).
+func TestBufferPodPending_DetectsUnschedulablePod(t *testing.T) {
+	dt := readyDeployment("autoscaling-buffer", 1)
+	pod := unschedulablePod("autoscaling-buffer-abc", "autoscaling-buffer")
+	cl := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(dt, pod).Build()
+
+	cond := getAutoscalingBufferReadyCondition(cl, testNamespace, []string{"autoscaling-buffer"})
+	if cond.Status != corev1.ConditionFalse || cond.Reason != ReasonAutoscalingBufferPodPending {
+		t.Fatalf("expected %s/%s, got %s/%s", corev1.ConditionFalse, ReasonAutoscalingBufferPodPending, cond.Status, cond.Reason)
+	}
+}
+
+// TestBufferPodPending_IgnoresUnrelatedPods asserts that pods belonging to a different Deployment
+// (different "app" label) don't affect the condition.
+func TestBufferPodPending_IgnoresUnrelatedPods(t *testing.T) {
+	dt := readyDeployment("autoscaling-buffer", 1)
+	otherPod := unschedulablePod("other-abc", "some-other-app")
+	cl := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(dt, otherPod).Build()
+
+	cond := getAutoscalingBufferReadyCondition(cl, testNamespace, []string{"autoscaling-buffer"})
+	if cond.Status != corev1.ConditionTrue || cond.Reason != ReasonAutoscalingBufferReady {
+		t.Fatalf("expected %s/%s, got %s/%s", corev1.ConditionTrue, ReasonAutoscalingBufferReady, cond.Status, cond.Reason)
+	}
+}
+
+func TestGetAutoscalingBufferReadyCondition_NotCreated(t *testing.T) {
+	cl := fake.NewClientBuilder().WithScheme(newScheme(t)).Build()
+
+	cond := getAutoscalingBufferReadyCondition(cl, testNamespace, []string{"autoscaling-buffer"})
+	if cond.Status != corev1.ConditionFalse || cond.Reason != ReasonAutoscalingBufferNotCreated {
+		t.Fatalf("expected %s/%s, got %s/%s", corev1.ConditionFalse, ReasonAutoscalingBufferNotCreated, cond.Status, cond.Reason)
+	}
+}
+
+func TestUpdateAutoscalingBufferCondition(t *testing.T) {
+	dt := readyDeployment("autoscaling-buffer", 1)
+	ms := &toolchainv1alpha1.MemberStatus{
+		ObjectMeta: metav1.ObjectMeta{Name: MemberStatusName, Namespace: testNamespace},
+	}
+	cl := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(dt, ms).WithStatusSubresource(ms).Build()
+
+	if err := UpdateAutoscalingBufferCondition(cl, testNamespace, []string{"autoscaling-buffer"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := &toolchainv1alpha1.MemberStatus{}
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: MemberStatusName, Namespace: testNamespace}, got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Status.Conditions) != 1 || got.Status.Conditions[0].Reason != ReasonAutoscalingBufferReady {
+		t.Fatalf("expected the AutoscalingBufferReady condition to be set, got %+v", got.Status.Conditions)
+	}
+}